@@ -0,0 +1,341 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver"
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// client retrieves the raw INFO payload, and the slowlog, from a single Redis node.
+type client interface {
+	retrieveInfo(ctx context.Context) (string, error)
+	slowLogGet(ctx context.Context, n int64) ([]redis.SlowLog, error)
+	slowLogReset(ctx context.Context) error
+}
+
+var _ client = (*redisClient)(nil)
+
+// redisClient is a client backed by a single *redis.Client connection.
+type redisClient struct {
+	delegate *redis.Client
+	// sections, if non-empty, are passed to INFO to restrict the sections requested.
+	sections []string
+}
+
+func (c *redisClient) retrieveInfo(ctx context.Context) (string, error) {
+	if len(c.sections) > 0 {
+		return c.delegate.Info(ctx, c.sections...).Result()
+	}
+
+	// The default sections returned by a bare INFO often omit commandstats, so it's
+	// requested explicitly and its output appended to the default response.
+	defaultSections, err := c.delegate.Info(ctx).Result()
+	if err != nil {
+		return "", err
+	}
+	commandStats, err := c.delegate.Info(ctx, "commandstats").Result()
+	if err != nil {
+		return "", err
+	}
+	return defaultSections + "\n" + commandStats, nil
+}
+
+func (c *redisClient) slowLogGet(ctx context.Context, n int64) ([]redis.SlowLog, error) {
+	return c.delegate.SlowLogGet(ctx, n).Result()
+}
+
+func (c *redisClient) slowLogReset(ctx context.Context) error {
+	return c.delegate.SlowLogReset(ctx).Err()
+}
+
+// nodeDescriptor carries the address, replication role, and (for cluster deployments) shard
+// of one node in a Redis deployment. It is used to populate per-node resource attributes so
+// that a node's metrics land on a distinct resource from its peers.
+type nodeDescriptor struct {
+	address string
+	role    string
+	shardID string
+}
+
+// nodeClient pairs a client with the nodeDescriptor describing the node it talks to.
+type nodeClient struct {
+	client
+	nodeDescriptor
+}
+
+// clientFactory discovers the set of nodes that make up a configured Redis deployment and
+// returns a nodeClient for each one. A standalone deployment always discovers exactly one
+// node; cluster and sentinel deployments may discover many. Implementations reuse the
+// underlying connections across calls, so nodeClients can be (and is) called once per
+// scrape; Close releases them once the receiver is shut down.
+type clientFactory interface {
+	nodeClients(ctx context.Context) ([]nodeClient, error)
+	Close() error
+}
+
+// newClientFactory builds the clientFactory appropriate for cfg.Mode.
+func newClientFactory(cfg *Config, opts *redis.Options) (clientFactory, error) {
+	switch cfg.Mode {
+	case ModeCluster:
+		return &clusterClientFactory{
+			opts: &redis.ClusterOptions{
+				Addrs:     cfg.ClusterAddrs,
+				Username:  opts.Username,
+				Password:  opts.Password,
+				TLSConfig: opts.TLSConfig,
+			},
+			sections: cfg.InfoSections,
+		}, nil
+	case ModeSentinel:
+		return &sentinelClientFactory{
+			masterName:    cfg.MasterName,
+			sentinelAddrs: cfg.SentinelAddrs,
+			opts:          opts,
+			sections:      cfg.InfoSections,
+		}, nil
+	case ModeStandalone, "":
+		return &standaloneClientFactory{opts: opts, sections: cfg.InfoSections}, nil
+	default:
+		return nil, fmt.Errorf("invalid mode %q", cfg.Mode)
+	}
+}
+
+// standaloneClientFactory always discovers a single node: the one addressed by cfg.Endpoint.
+// Its *redis.Client is constructed once, on first use, and reused for the life of the
+// receiver rather than redialed on every scrape.
+type standaloneClientFactory struct {
+	opts     *redis.Options
+	sections []string
+
+	mu     sync.Mutex
+	client *redisClient
+}
+
+func (f *standaloneClientFactory) nodeClients(context.Context) ([]nodeClient, error) {
+	f.mu.Lock()
+	if f.client == nil {
+		f.client = &redisClient{delegate: redis.NewClient(f.opts), sections: f.sections}
+	}
+	client := f.client
+	f.mu.Unlock()
+
+	return []nodeClient{{
+		client:         client,
+		nodeDescriptor: nodeDescriptor{address: f.opts.Addr, role: "master"},
+	}}, nil
+}
+
+func (f *standaloneClientFactory) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.client == nil {
+		return nil
+	}
+	err := f.client.delegate.Close()
+	f.client = nil
+	return err
+}
+
+// clusterClientFactory discovers every master and replica in a Redis Cluster, reached
+// through the seed addresses in ClusterAddrs. Its *redis.ClusterClient is constructed once,
+// on first use, and reused for the life of the receiver rather than redialed on every scrape.
+type clusterClientFactory struct {
+	opts     *redis.ClusterOptions
+	sections []string
+
+	mu sync.Mutex
+	cc *redis.ClusterClient
+}
+
+func (f *clusterClientFactory) client() *redis.ClusterClient {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cc == nil {
+		f.cc = redis.NewClusterClient(f.opts)
+	}
+	return f.cc
+}
+
+func (f *clusterClientFactory) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cc == nil {
+		return nil
+	}
+	err := f.cc.Close()
+	f.cc = nil
+	return err
+}
+
+func (f *clusterClientFactory) nodeClients(ctx context.Context) ([]nodeClient, error) {
+	cc := f.client()
+
+	var (
+		mu      sync.Mutex
+		clients []nodeClient
+	)
+	collect := func(role string) func(context.Context, *redis.Client) error {
+		return func(ctx context.Context, node *redis.Client) error {
+			shardID, err := clusterShardID(ctx, node)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			clients = append(clients, nodeClient{
+				client: &redisClient{delegate: node, sections: f.sections},
+				nodeDescriptor: nodeDescriptor{
+					address: node.Options().Addr,
+					role:    role,
+					shardID: shardID,
+				},
+			})
+			return nil
+		}
+	}
+	if err := cc.ForEachMaster(ctx, collect("master")); err != nil {
+		return nil, fmt.Errorf("discovering cluster masters: %w", err)
+	}
+	if err := cc.ForEachSlave(ctx, collect("replica")); err != nil {
+		return nil, fmt.Errorf("discovering cluster replicas: %w", err)
+	}
+	return clients, nil
+}
+
+// clusterShardID returns the first hash slot owned by node, used as a stable shard
+// identifier since Redis Cluster does not expose shard IDs directly. CLUSTER SLOTS
+// returns the same cluster-wide slot map regardless of which node it's sent to, so the
+// slot range belonging to node itself is found by matching its own address against each
+// slot's Nodes list rather than just taking the first slot in the reply.
+func clusterShardID(ctx context.Context, node *redis.Client) (string, error) {
+	slots, err := node.ClusterSlots(ctx).Result()
+	if err != nil {
+		return "", fmt.Errorf("reading cluster slots: %w", err)
+	}
+	addr := node.Options().Addr
+	for _, slot := range slots {
+		for _, n := range slot.Nodes {
+			if n.Addr == addr {
+				return strconv.Itoa(slot.Start), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// sentinelClientFactory resolves the current master and its replicas for MasterName
+// through one of SentinelAddrs, then connects to each directly. A *redis.Client is cached
+// per node address and reused across scrapes; addresses that drop out of the topology
+// (e.g. after a failover) have their client closed and evicted.
+type sentinelClientFactory struct {
+	masterName    string
+	sentinelAddrs []string
+	opts          *redis.Options
+	sections      []string
+
+	mu      sync.Mutex
+	clients map[string]*redisClient
+}
+
+func (f *sentinelClientFactory) nodeClients(ctx context.Context) ([]nodeClient, error) {
+	sentinel := redis.NewSentinelClient(&redis.Options{
+		Addr:      f.sentinelAddrs[0],
+		Username:  f.opts.Username,
+		Password:  f.opts.Password,
+		TLSConfig: f.opts.TLSConfig,
+	})
+	defer sentinel.Close()
+
+	masterAddr, err := sentinel.GetMasterAddrByName(ctx, f.masterName).Result()
+	if err != nil {
+		return nil, fmt.Errorf("resolving master %q via sentinel: %w", f.masterName, err)
+	}
+	replicas, err := sentinel.Slaves(ctx, f.masterName).Result()
+	if err != nil {
+		return nil, fmt.Errorf("resolving replicas of %q via sentinel: %w", f.masterName, err)
+	}
+
+	descriptors := []nodeDescriptor{{address: fmt.Sprintf("%s:%s", masterAddr[0], masterAddr[1]), role: "master"}}
+	for _, replica := range replicas {
+		ip, port, ok := sentinelReplicaAddr(replica)
+		if !ok {
+			continue
+		}
+		descriptors = append(descriptors, nodeDescriptor{address: fmt.Sprintf("%s:%s", ip, port), role: "replica"})
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.clients == nil {
+		f.clients = map[string]*redisClient{}
+	}
+	current := make(map[string]struct{}, len(descriptors))
+	clients := make([]nodeClient, 0, len(descriptors))
+	for _, nd := range descriptors {
+		current[nd.address] = struct{}{}
+		clients = append(clients, nodeClient{client: f.node(nd.address), nodeDescriptor: nd})
+	}
+	for addr, client := range f.clients {
+		if _, ok := current[addr]; ok {
+			continue
+		}
+		client.delegate.Close()
+		delete(f.clients, addr)
+	}
+	return clients, nil
+}
+
+// node returns the cached client for addr, creating and caching one if this is the first
+// time addr has been seen. Callers must hold f.mu.
+func (f *sentinelClientFactory) node(addr string) *redisClient {
+	if client, ok := f.clients[addr]; ok {
+		return client
+	}
+	opts := *f.opts
+	opts.Addr = addr
+	client := &redisClient{delegate: redis.NewClient(&opts), sections: f.sections}
+	f.clients[addr] = client
+	return client
+}
+
+func (f *sentinelClientFactory) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var err error
+	for addr, client := range f.clients {
+		if closeErr := client.delegate.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		delete(f.clients, addr)
+	}
+	return err
+}
+
+// sentinelReplicaAddr extracts the ip/port pair from one entry of the reply to the
+// Sentinel `SLAVES <master>` command, which go-redis surfaces as a flat string map.
+func sentinelReplicaAddr(replica interface{}) (ip, port string, ok bool) {
+	fields, isMap := replica.(map[string]string)
+	if !isMap {
+		return "", "", false
+	}
+	ip, hasIP := fields["ip"]
+	port, hasPort := fields["port"]
+	return ip, port, hasIP && hasPort
+}
@@ -0,0 +1,102 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// fakeClient is a client that always returns canned INFO output, for use in tests.
+type fakeClient struct{}
+
+func (c *fakeClient) retrieveInfo(context.Context) (string, error) {
+	return fakeInfo, nil
+}
+
+func (c *fakeClient) slowLogGet(context.Context, int64) ([]redis.SlowLog, error) {
+	return nil, nil
+}
+
+func (c *fakeClient) slowLogReset(context.Context) error {
+	return nil
+}
+
+// fakeClientFactory is a clientFactory that discovers a single fakeClient node, for use in tests.
+type fakeClientFactory struct{}
+
+// newFakeClient returns a clientFactory that always yields the same canned INFO payload,
+// standing in for a real standalone Redis node in tests.
+func newFakeClient() clientFactory {
+	return &fakeClientFactory{}
+}
+
+func (f *fakeClientFactory) nodeClients(context.Context) ([]nodeClient, error) {
+	return []nodeClient{{
+		client:         &fakeClient{},
+		nodeDescriptor: nodeDescriptor{address: "localhost:6379", role: "master"},
+	}}, nil
+}
+
+func (f *fakeClientFactory) Close() error {
+	return nil
+}
+
+const fakeInfo = `# Server
+redis_version:6.2.6
+uptime_in_seconds:104
+
+# Clients
+connected_clients:2
+blocked_clients:0
+
+# Memory
+used_memory:854864
+used_memory_rss:7733248
+used_memory_peak:987712
+used_memory_lua:37888
+
+# CPU
+used_cpu_sys:0.064089
+used_cpu_user:0.085229
+
+# Stats
+total_connections_received:2
+total_commands_processed:25
+instantaneous_ops_per_sec:0
+rejected_connections:0
+keyspace_hits:2
+keyspace_misses:0
+
+# Replication
+connected_slaves:0
+master_repl_offset:0
+repl_backlog_first_byte_offset:0
+
+# Keyspace
+db0:keys=1,expires=0,avg_ttl=0
+db1:keys=2,expires=1,avg_ttl=1000
+
+# Commandstats
+cmdstat_get:calls=1,usec=10,usec_per_call=10.00,rejected_calls=0,failed_calls=0
+
+# Latencystats
+latency_percentiles_usec_get:p50=10.003,p99=30.003,p99.9=40.003
+latency_percentiles_usec_set:p50=20.003,p99=40.003,p99.9=50.003
+latency_percentiles_usec_dbsize:p50=30.345,p99=50.345,p99.9=60.345
+latency_percentiles_usec_info:p50=5.001,p99=6.001,p99.9=7.001
+latency_percentiles_usec_ping:p50=1.001,p99=2.001,p99.9=3.001
+`
@@ -0,0 +1,60 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// commandstats represents the parsed contents of one `cmdstat_<command>:` line of a Redis
+// INFO response, e.g. "calls=2,usec=14,usec_per_call=7.00,rejected_calls=0,failed_calls=0".
+type commandstats struct {
+	command       string
+	calls         int64
+	usec          int64
+	usecPerCall   float64
+	rejectedCalls int64
+	failedCalls   int64
+}
+
+// parseCommandStatsString parses one `cmdstat_<command>:` line of a Redis INFO response.
+func parseCommandStatsString(command, str string) (*commandstats, error) {
+	stats := &commandstats{command: command}
+	for _, pair := range strings.Split(str, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("unexpected commandstats field %q", pair)
+		}
+		var err error
+		switch kv[0] {
+		case "calls":
+			stats.calls, err = strconv.ParseInt(kv[1], 10, 64)
+		case "usec":
+			stats.usec, err = strconv.ParseInt(kv[1], 10, 64)
+		case "usec_per_call":
+			stats.usecPerCall, err = strconv.ParseFloat(kv[1], 64)
+		case "rejected_calls":
+			stats.rejectedCalls, err = strconv.ParseInt(kv[1], 10, 64)
+		case "failed_calls":
+			stats.failedCalls, err = strconv.ParseInt(kv[1], 10, 64)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("parsing commandstats field %q: %w", kv[0], err)
+		}
+	}
+	return stats, nil
+}
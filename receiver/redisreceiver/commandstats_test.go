@@ -0,0 +1,38 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCommandStatsString(t *testing.T) {
+	stats, err := parseCommandStatsString("get", "calls=2,usec=14,usec_per_call=7.00,rejected_calls=1,failed_calls=0")
+	require.NoError(t, err)
+	assert.Equal(t, "get", stats.command)
+	assert.Equal(t, int64(2), stats.calls)
+	assert.Equal(t, int64(14), stats.usec)
+	assert.Equal(t, 7.0, stats.usecPerCall)
+	assert.Equal(t, int64(1), stats.rejectedCalls)
+	assert.Equal(t, int64(0), stats.failedCalls)
+}
+
+func TestParseCommandStatsStringInvalid(t *testing.T) {
+	_, err := parseCommandStatsString("get", "calls=notanumber")
+	assert.Error(t, err)
+}
@@ -0,0 +1,196 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver"
+
+import (
+	"fmt"
+	"path"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver/internal/metadata"
+)
+
+// Mode selects the topology of the Redis deployment the receiver connects to.
+type Mode string
+
+const (
+	// ModeStandalone connects to a single Redis instance at Endpoint. This is the default.
+	ModeStandalone Mode = "standalone"
+	// ModeCluster connects to a Redis Cluster, discovered through ClusterAddrs.
+	ModeCluster Mode = "cluster"
+	// ModeSentinel connects to a Redis deployment managed by Sentinel, resolved through
+	// SentinelAddrs and MasterName.
+	ModeSentinel Mode = "sentinel"
+)
+
+// Config is the configuration for the Redis receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+	confignet.NetAddr                       `mapstructure:",squash"`
+	TLS                                     configtls.TLSClientSetting `mapstructure:"tls"`
+	Username                                string                     `mapstructure:"username"`
+	Password                                string                     `mapstructure:"password"`
+
+	// Mode selects the Redis topology: "standalone" (default), "cluster", or "sentinel".
+	Mode Mode `mapstructure:"mode"`
+	// MasterName is the name of the monitored master set. Required when Mode is "sentinel".
+	MasterName string `mapstructure:"master_name"`
+	// SentinelAddrs lists the Sentinel instances used to resolve the current master and
+	// its replicas. Only the first address is queried; the others are not contacted, even
+	// when the first is unreachable. Required when Mode is "sentinel".
+	SentinelAddrs []string `mapstructure:"sentinel_addrs"`
+	// ClusterAddrs lists the seed addresses used to discover the rest of a Redis Cluster.
+	// Required when Mode is "cluster".
+	ClusterAddrs []string `mapstructure:"cluster_addrs"`
+
+	// Flavor selects the Redis-protocol-compatible server being scraped: "redis", "pika", or
+	// "keydb". When left empty (the default), the flavor is auto-detected from INFO.
+	Flavor Flavor `mapstructure:"flavor"`
+
+	// Slowlog configures collection of the Redis slowlog as logs.
+	Slowlog SlowlogSettings `mapstructure:"slowlog"`
+
+	// InfoSections lists the `INFO` sections to request, e.g. ["server", "stats", "keyspace"].
+	// When empty (the default), the receiver requests every section via a bare `INFO`, plus
+	// `commandstats` explicitly since some servers omit it from the default response.
+	InfoSections []string `mapstructure:"info_sections"`
+
+	// Keyspace configures which Redis databases are scraped for keyspace metrics.
+	Keyspace KeyspaceSettings `mapstructure:"keyspace"`
+
+	// MetricFilters restricts which metrics the receiver emits.
+	MetricFilters MetricFilterSettings `mapstructure:"metric_filters"`
+
+	Metrics metadata.MetricsSettings `mapstructure:"metrics"`
+}
+
+// KeyspaceSettings configures collection of keyspace metrics.
+type KeyspaceSettings struct {
+	// Databases overrides the set of Redis database indices scraped for keyspace metrics.
+	// When empty (the default), every index from 0 to 15 is scraped.
+	Databases []int `mapstructure:"databases"`
+}
+
+// MetricFilterSettings is a pair of glob allow/deny lists applied to metric names, evaluated
+// in the same order as Prometheus relabeling: a metric must match an Include pattern (if any
+// are configured), and must not match an Exclude pattern, to be emitted.
+type MetricFilterSettings struct {
+	// Include, if non-empty, restricts emitted metrics to those matching at least one of
+	// these glob patterns.
+	Include []string `mapstructure:"include"`
+	// Exclude drops any metric matching one of these glob patterns, evaluated after Include.
+	Exclude []string `mapstructure:"exclude"`
+}
+
+// allows reports whether name passes cfg's Include/Exclude glob lists.
+func (cfg MetricFilterSettings) allows(name string) bool {
+	if len(cfg.Include) > 0 && !matchesAny(cfg.Include, name) {
+		return false
+	}
+	return !matchesAny(cfg.Exclude, name)
+}
+
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// knownInfoSections are the section names recognized by Redis' `INFO` command, plus the
+// meta-sections `all`, `default`, and `everything` that Redis itself accepts.
+var knownInfoSections = map[string]struct{}{
+	"server": {}, "clients": {}, "memory": {}, "persistence": {}, "stats": {},
+	"replication": {}, "cpu": {}, "commandstats": {}, "latencystats": {}, "errorstats": {},
+	"cluster": {}, "keyspace": {}, "all": {}, "default": {}, "everything": {},
+}
+
+// SlowlogSettings configures the optional slowlog logs pipeline.
+type SlowlogSettings struct {
+	// Enabled turns on periodic collection of the Redis slowlog as logs. Disabled by default.
+	Enabled bool `mapstructure:"enabled"`
+	// MaxEntries is the maximum number of slowlog entries fetched per scrape, passed as the
+	// <count> argument of `SLOWLOG GET <count>`.
+	MaxEntries int64 `mapstructure:"max_entries"`
+	// ResetAfterRead issues `SLOWLOG RESET` after each scrape. When false (the default), the
+	// receiver instead de-duplicates entries by their slowlog id across scrapes.
+	ResetAfterRead bool `mapstructure:"reset_after_read"`
+}
+
+// Validate checks that the receiver configuration is self-consistent.
+func (cfg *Config) Validate() error {
+	switch cfg.Mode {
+	case ModeStandalone, "":
+	case ModeCluster:
+		if len(cfg.ClusterAddrs) == 0 {
+			return fmt.Errorf("cluster_addrs must be specified when mode is %q", ModeCluster)
+		}
+	case ModeSentinel:
+		if len(cfg.SentinelAddrs) == 0 {
+			return fmt.Errorf("sentinel_addrs must be specified when mode is %q", ModeSentinel)
+		}
+		if cfg.MasterName == "" {
+			return fmt.Errorf("master_name must be specified when mode is %q", ModeSentinel)
+		}
+	default:
+		return fmt.Errorf("invalid mode %q: must be one of %q, %q, %q", cfg.Mode, ModeStandalone, ModeCluster, ModeSentinel)
+	}
+
+	switch cfg.Flavor {
+	case FlavorRedis, FlavorPika, FlavorKeyDB, "":
+	default:
+		return fmt.Errorf("invalid flavor %q: must be one of %q, %q, %q", cfg.Flavor, FlavorRedis, FlavorPika, FlavorKeyDB)
+	}
+
+	if cfg.Slowlog.Enabled && cfg.Slowlog.MaxEntries <= 0 {
+		return fmt.Errorf("slowlog.max_entries must be positive, got %d", cfg.Slowlog.MaxEntries)
+	}
+
+	for _, section := range cfg.InfoSections {
+		if _, ok := knownInfoSections[section]; !ok {
+			return fmt.Errorf("invalid info_sections entry %q", section)
+		}
+	}
+
+	for _, pattern := range append(append([]string{}, cfg.MetricFilters.Include...), cfg.MetricFilters.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid metric_filters pattern %q: %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+// redisOptions builds the go-redis options used to dial the node(s) described by cfg,
+// shared by both the metrics scraper and the slowlog logs receiver.
+func (cfg *Config) redisOptions() (*redis.Options, error) {
+	opts := &redis.Options{
+		Addr:     cfg.Endpoint,
+		Username: cfg.Username,
+		Password: cfg.Password,
+		Network:  cfg.Transport,
+	}
+	var err error
+	if opts.TLSConfig, err = cfg.TLS.LoadTLSConfig(); err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
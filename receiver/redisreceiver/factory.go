@@ -0,0 +1,96 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver"
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confignet"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver/internal/metadata"
+)
+
+const typeStr = "redis"
+
+var errConfigNotRedis = errors.New("config was not a Redis receiver config")
+var errSlowlogNotEnabled = errors.New("a logs pipeline requires slowlog.enabled to be set to true")
+
+// NewFactory creates a factory for the Redis receiver.
+func NewFactory() component.ReceiverFactory {
+	return component.NewReceiverFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithMetricsReceiver(createMetricsReceiver, component.StabilityLevelBeta),
+		component.WithLogsReceiver(createLogsReceiver, component.StabilityLevelAlpha))
+}
+
+func createDefaultConfig() config.Receiver {
+	return &Config{
+		ScraperControllerSettings: scraperhelper.NewDefaultScraperControllerSettings(typeStr),
+		NetAddr: confignet.NetAddr{
+			Endpoint:  "localhost:6379",
+			Transport: "tcp",
+		},
+		Mode: ModeStandalone,
+		Slowlog: SlowlogSettings{
+			Enabled:    false,
+			MaxEntries: 128,
+		},
+		Metrics: metadata.DefaultMetricsSettings(),
+	}
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	settings component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	consumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	redisCfg, ok := cfg.(*Config)
+	if !ok {
+		return nil, errConfigNotRedis
+	}
+	scrpr, err := newRedisScraper(redisCfg, settings)
+	if err != nil {
+		return nil, err
+	}
+	return scraperhelper.NewScraperControllerReceiver(
+		&redisCfg.ScraperControllerSettings,
+		settings,
+		consumer,
+		scraperhelper.AddScraper(scrpr),
+	)
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	settings component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	consumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	redisCfg, ok := cfg.(*Config)
+	if !ok {
+		return nil, errConfigNotRedis
+	}
+	if !redisCfg.Slowlog.Enabled {
+		return nil, errSlowlogNotEnabled
+	}
+	return newLogsReceiver(redisCfg, settings, consumer)
+}
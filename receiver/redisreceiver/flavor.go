@@ -0,0 +1,126 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver"
+
+import (
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+// Flavor identifies which Redis-protocol-compatible server a node is running, since the
+// `INFO` sections a server exposes (and the meaning of some of their fields) can differ
+// between them.
+type Flavor string
+
+const (
+	// FlavorRedis is plain Redis (or a server indistinguishable from it). This is the default.
+	FlavorRedis Flavor = "redis"
+	// FlavorPika is Pika, a disk-based, RocksDB-backed Redis-protocol-compatible server.
+	FlavorPika Flavor = "pika"
+	// FlavorKeyDB is KeyDB, a multithreaded Redis fork with active-active replication.
+	FlavorKeyDB Flavor = "keydb"
+)
+
+// detectFlavor infers the Flavor of the server a node's INFO output came from. Pika reports
+// its version under `pika_version` instead of `redis_version`; KeyDB reports both
+// `redis_version` (for client compatibility) and a `keydb_version` field.
+func detectFlavor(inf info) Flavor {
+	if _, ok := inf["pika_version"]; ok {
+		return FlavorPika
+	}
+	if _, ok := inf["keydb_version"]; ok {
+		return FlavorKeyDB
+	}
+	return FlavorRedis
+}
+
+// recordFlavorMetrics records the metrics specific to cfg.Flavor (or the auto-detected
+// flavor, if cfg.Flavor is empty), parsing the additional INFO sections those servers expose.
+func (rs *redisScraper) recordFlavorMetrics(ts pcommon.Timestamp, flavor Flavor, inf info) {
+	switch flavor {
+	case FlavorPika:
+		rs.recordPikaMetrics(ts, inf)
+	case FlavorKeyDB:
+		rs.recordKeyDBMetrics(ts, inf)
+	}
+}
+
+// recordPikaMetrics records metrics from the `# RocksDB` and per-DB `used_memory` sections
+// that Pika adds to INFO, plus its replication binlog offset.
+func (rs *redisScraper) recordPikaMetrics(ts pcommon.Timestamp, inf info) {
+	if raw, ok := inf["compactions_in_progress"]; ok {
+		// Pika exposes a running count, not a cumulative counter, so this is a gauge.
+		if val, err := strconv.ParseInt(raw, 10, 64); err == nil {
+			if rs.metricFilters.allows("redis.pika.rocksdb.compactions_in_progress") {
+				rs.mb.RecordRedisPikaRocksdbCompactionsInProgressDataPoint(ts, val)
+			}
+		} else {
+			rs.settings.Logger.Warn("failed to parse pika rocksdb stat", zap.String("val", raw), zap.Error(err))
+		}
+	}
+
+	if raw, ok := inf["binlog_offset"]; ok {
+		// binlog_offset is reported as "<file_num> <offset>"; the offset is what's useful
+		// for tracking replication lag between a master and its replicas.
+		fields := strings.Fields(raw)
+		if len(fields) == 2 {
+			if val, err := strconv.ParseInt(fields[1], 10, 64); err == nil {
+				if rs.metricFilters.allows("redis.pika.replication.binlog_offset") {
+					rs.mb.RecordRedisPikaReplicationBinlogOffsetDataPoint(ts, val)
+				}
+			} else {
+				rs.settings.Logger.Warn("failed to parse pika binlog_offset", zap.String("val", raw), zap.Error(err))
+			}
+		}
+	}
+
+	if !rs.metricFilters.allows("redis.pika.db.memory_used") {
+		return
+	}
+	for db := 0; db < redisMaxDbs; db++ {
+		key := "db" + strconv.Itoa(db) + "_used_memory"
+		raw, ok := inf[key]
+		if !ok {
+			continue
+		}
+		val, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			rs.settings.Logger.Warn("failed to parse pika per-db memory stat", zap.String("key", key),
+				zap.String("val", raw), zap.Error(err))
+			continue
+		}
+		rs.mb.RecordRedisPikaDbMemoryUsedDataPoint(ts, val, strconv.Itoa(db))
+	}
+}
+
+// recordKeyDBMetrics records the active-active replication lag KeyDB adds to INFO.
+func (rs *redisScraper) recordKeyDBMetrics(ts pcommon.Timestamp, inf info) {
+	if !rs.metricFilters.allows("redis.keydb.replication.lag") {
+		return
+	}
+	raw, ok := inf["mvcc_replication_lag"]
+	if !ok {
+		return
+	}
+	val, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		rs.settings.Logger.Warn("failed to parse keydb replication lag", zap.String("val", raw), zap.Error(err))
+		return
+	}
+	rs.mb.RecordRedisKeydbReplicationLagDataPoint(ts, val)
+}
@@ -0,0 +1,50 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectFlavor(t *testing.T) {
+	tests := []struct {
+		name string
+		inf  info
+		want Flavor
+	}{
+		{
+			name: "plain redis",
+			inf:  info{"redis_version": "6.2.6"},
+			want: FlavorRedis,
+		},
+		{
+			name: "pika",
+			inf:  info{"pika_version": "3.5.0"},
+			want: FlavorPika,
+		},
+		{
+			name: "keydb",
+			inf:  info{"redis_version": "6.2.6", "keydb_version": "6.3.2"},
+			want: FlavorKeyDB,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, detectFlavor(tt.inf))
+		})
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver"
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// info is a parsed view of the flat key/value pairs returned by Redis' INFO command (or
+// one of its variants, e.g. `INFO commandstats`). Lines that are empty, or that start a
+// new `# Section` header, are dropped; everything else is expected to be `key:value`.
+type info map[string]string
+
+// parseInfo parses the response of an INFO command into a key/value map.
+func parseInfo(str string) (info, error) {
+	infoMap := map[string]string{}
+	rdr := strings.NewReader(str)
+	scanner := bufio.NewScanner(rdr)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		tokens := strings.SplitN(line, ":", 2)
+		if len(tokens) != 2 {
+			continue
+		}
+		infoMap[tokens[0]] = tokens[1]
+	}
+	return infoMap, scanner.Err()
+}
+
+// getUptimeInSeconds returns the "uptime_in_seconds" value reported by Redis.
+func (i info) getUptimeInSeconds() (time.Duration, error) {
+	const uptimeKey = "uptime_in_seconds"
+	uptime, ok := i[uptimeKey]
+	if !ok {
+		return 0, fmt.Errorf("missing info key %q", uptimeKey)
+	}
+	seconds, err := strconv.ParseInt(uptime, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parsing %q: %w", uptimeKey, err)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
@@ -0,0 +1,54 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// keyspace represents the parsed contents of one `dbN:` line of a Redis INFO response,
+// e.g. "db0:keys=1,expires=2,avg_ttl=3".
+type keyspace struct {
+	db      string
+	keys    int
+	expires int
+	avgTTL  int
+}
+
+// parseKeyspaceString parses one `dbN:` line of a Redis INFO response.
+func parseKeyspaceString(db int, str string) (*keyspace, error) {
+	keyspace := &keyspace{db: strconv.Itoa(db)}
+	for _, pair := range strings.Split(str, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("unexpected keyspace field %q", pair)
+		}
+		val, err := strconv.Atoi(kv[1])
+		if err != nil {
+			return nil, fmt.Errorf("parsing keyspace field %q: %w", kv[0], err)
+		}
+		switch kv[0] {
+		case "keys":
+			keyspace.keys = val
+		case "expires":
+			keyspace.expires = val
+		case "avg_ttl":
+			keyspace.avgTTL = val
+		}
+	}
+	return keyspace, nil
+}
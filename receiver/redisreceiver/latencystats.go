@@ -0,0 +1,45 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// latencystats represents the parsed contents of one `latency_percentiles_usec_<command>:` line
+// of a Redis INFO response, e.g. "p50=10.123,p99=110.234,p99.9=120.234".
+type latencystats struct {
+	command string
+	stats   map[string]float64
+}
+
+// parseLatencystatsString parses one `latency_percentiles_usec_<command>:` line of a Redis INFO response.
+func parseLatencystatsString(command, str string) (*latencystats, error) {
+	stats := map[string]float64{}
+	for _, pair := range strings.Split(str, ",") {
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("unexpected latency stats field %q", pair)
+		}
+		val, err := strconv.ParseFloat(kv[1], 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing latency stats field %q: %w", kv[0], err)
+		}
+		stats[kv[0]] = val
+	}
+	return &latencystats{command: command, stats: stats}, nil
+}
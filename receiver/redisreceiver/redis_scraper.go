@@ -20,7 +20,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/go-redis/redis/v7"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -30,70 +29,142 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver/internal/metadata"
 )
 
-// Runs intermittently, fetching info from Redis, creating metrics/datapoints,
-// and feeding them to a metricsConsumer.
+// Runs intermittently, fetching info from every node of a Redis deployment, creating
+// metrics/datapoints, and feeding them to a metricsConsumer.
 type redisScraper struct {
-	redisSvc *redisSvc
-	settings component.ReceiverCreateSettings
-	mb       *metadata.MetricsBuilder
-	uptime   time.Duration
+	redisSvc          *redisSvc
+	settings          component.ReceiverCreateSettings
+	mb                *metadata.MetricsBuilder
+	uptime            time.Duration
+	flavor            Flavor
+	keyspaceDatabases []int
+	metricFilters     MetricFilterSettings
 }
 
 const redisMaxDbs = 16 // Maximum possible number of redis databases
 
 func newRedisScraper(cfg *Config, settings component.ReceiverCreateSettings) (scraperhelper.Scraper, error) {
-	opts := &redis.Options{
-		Addr:     cfg.Endpoint,
-		Password: cfg.Password,
-		Network:  cfg.Transport,
+	opts, err := cfg.redisOptions()
+	if err != nil {
+		return nil, err
 	}
 
-	var err error
-	if opts.TLSConfig, err = cfg.TLS.LoadTLSConfig(); err != nil {
+	factory, err := newClientFactory(cfg, opts)
+	if err != nil {
 		return nil, err
 	}
-	return newRedisScraperWithClient(newRedisClient(opts), settings, cfg)
+	return newRedisScraperWithClient(factory, settings, cfg)
 }
 
-func newRedisScraperWithClient(client client, settings component.ReceiverCreateSettings, cfg *Config) (scraperhelper.Scraper, error) {
+func newRedisScraperWithClient(factory clientFactory, settings component.ReceiverCreateSettings, cfg *Config) (scraperhelper.Scraper, error) {
 	rs := &redisScraper{
-		redisSvc: newRedisSvc(client),
-		settings: settings,
-		mb:       metadata.NewMetricsBuilder(cfg.Metrics),
+		redisSvc:          newRedisSvc(factory),
+		settings:          settings,
+		mb:                metadata.NewMetricsBuilder(cfg.Metrics),
+		flavor:            cfg.Flavor,
+		keyspaceDatabases: keyspaceDatabases(cfg),
+		metricFilters:     cfg.MetricFilters,
 	}
-	return scraperhelper.NewScraper(typeStr, rs.Scrape)
+	return scraperhelper.NewScraper(typeStr, rs.Scrape, scraperhelper.WithShutdown(rs.Shutdown))
 }
 
-// Scrape is called periodically, querying Redis and building Metrics to send to
-// the next consumer. First builds 'fixed' metrics (non-keyspace metrics)
-// defined at startup time. Then builds 'keyspace' metrics if there are any
-// keyspace lines returned by Redis. There should be one keyspace line per
-// active Redis database, of which there can be 16.
-func (rs *redisScraper) Scrape(context.Context) (pmetric.Metrics, error) {
-	inf, err := rs.redisSvc.info()
-	if err != nil {
-		return pmetric.Metrics{}, err
+// Shutdown releases the connections the scraper's clientFactory opened to the monitored
+// Redis deployment.
+func (rs *redisScraper) Shutdown(context.Context) error {
+	return rs.redisSvc.Close()
+}
+
+// keyspaceDatabases returns the Redis database indices to scrape for keyspace metrics: the
+// databases configured in cfg.Keyspace.Databases, or every index from 0 to redisMaxDbs-1 when
+// that's left unset.
+func keyspaceDatabases(cfg *Config) []int {
+	if len(cfg.Keyspace.Databases) > 0 {
+		return cfg.Keyspace.Databases
+	}
+	dbs := make([]int, redisMaxDbs)
+	for i := range dbs {
+		dbs[i] = i
 	}
+	return dbs
+}
 
-	now := pcommon.NewTimestampFromTime(time.Now())
-	currentUptime, err := inf.getUptimeInSeconds()
+// Scrape is called periodically, querying every node of the Redis deployment and building
+// Metrics to send to the next consumer. Each node's metrics are emitted under their own
+// resource, tagged with that node's address and replication role, so that e.g. a Redis
+// Cluster's masters and replicas don't get collapsed into a single resource.
+func (rs *redisScraper) Scrape(ctx context.Context) (pmetric.Metrics, error) {
+	nodes, err := rs.redisSvc.info(ctx)
 	if err != nil {
 		return pmetric.Metrics{}, err
 	}
 
-	if rs.uptime == time.Duration(0) || rs.uptime > currentUptime {
-		rs.mb.Reset(metadata.WithStartTime(pcommon.NewTimestampFromTime(now.AsTime().Add(-currentUptime))))
-	}
-	rs.uptime = currentUptime
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for _, node := range nodes {
+		currentUptime, err := node.info.getUptimeInSeconds()
+		if err != nil {
+			return pmetric.Metrics{}, err
+		}
+
+		if rs.uptime == time.Duration(0) || rs.uptime > currentUptime {
+			rs.mb.Reset(metadata.WithStartTime(pcommon.NewTimestampFromTime(now.AsTime().Add(-currentUptime))))
+		}
+		rs.uptime = currentUptime
 
-	rs.recordCommonMetrics(now, inf)
-	rs.recordKeyspaceMetrics(now, inf)
-	rs.recordLatencyStatsMetrics(now, inf)
+		flavor := rs.flavor
+		if flavor == "" {
+			flavor = detectFlavor(node.info)
+		}
+
+		rs.recordCommonMetrics(now, node.info)
+		rs.recordKeyspaceMetrics(now, node.info)
+		rs.recordLatencyStatsMetrics(now, node.info)
+		rs.recordCommandStatsMetrics(now, node.info)
+		rs.recordFlavorMetrics(now, flavor, node.info)
+
+		rs.mb.EmitForResource(
+			metadata.WithRedisNodeAddress(node.address),
+			metadata.WithRedisNodeRole(node.role),
+			metadata.WithRedisClusterShardID(node.shardID),
+		)
+	}
 
 	return rs.mb.Emit(), nil
 }
 
-// recordCommonMetrics records metrics from Redis info key-value pairs.
+// dataPointRecorder pairs the metric name a Redis INFO key feeds, used to evaluate
+// MetricFilterSettings, with the MetricsBuilder method that records it.
+type dataPointRecorder struct {
+	metric string
+	record interface{}
+}
+
+// dataPointRecorders maps each Redis INFO key this scraper understands to the MetricsBuilder
+// method that records it.
+func (rs *redisScraper) dataPointRecorders() map[string]dataPointRecorder {
+	mb := rs.mb
+	return map[string]dataPointRecorder{
+		"uptime_in_seconds":              {"redis.uptime", mb.RecordRedisUptimeDataPoint},
+		"connected_clients":              {"redis.clients.connected", mb.RecordRedisClientsConnectedDataPoint},
+		"blocked_clients":                {"redis.clients.blocked", mb.RecordRedisClientsBlockedDataPoint},
+		"used_memory":                    {"redis.memory.used", mb.RecordRedisMemoryUsedDataPoint},
+		"used_memory_peak":               {"redis.memory.peak", mb.RecordRedisMemoryPeakDataPoint},
+		"used_memory_rss":                {"redis.memory.rss", mb.RecordRedisMemoryRssDataPoint},
+		"used_memory_lua":                {"redis.memory.lua", mb.RecordRedisMemoryLuaDataPoint},
+		"used_cpu_sys":                   {"redis.cpu.time", mb.RecordRedisCPUTimeDataPoint},
+		"total_commands_processed":       {"redis.commands.processed", mb.RecordRedisCommandsProcessedDataPoint},
+		"instantaneous_ops_per_sec":      {"redis.commands", mb.RecordRedisCommandsDataPoint},
+		"total_connections_received":     {"redis.connections.received", mb.RecordRedisConnectionsReceivedDataPoint},
+		"rejected_connections":           {"redis.connections.rejected", mb.RecordRedisConnectionsRejectedDataPoint},
+		"keyspace_hits":                  {"redis.keyspace.hits", mb.RecordRedisKeyspaceHitsDataPoint},
+		"keyspace_misses":                {"redis.keyspace.misses", mb.RecordRedisKeyspaceMissesDataPoint},
+		"repl_backlog_first_byte_offset": {"redis.replication.backlog_first_byte_offset", mb.RecordRedisReplicationBacklogFirstByteOffsetDataPoint},
+		"master_repl_offset":             {"redis.replication.offset", mb.RecordRedisReplicationOffsetDataPoint},
+		"connected_slaves":               {"redis.slaves.connected", mb.RecordRedisSlavesConnectedDataPoint},
+	}
+}
+
+// recordCommonMetrics records metrics from Redis info key-value pairs, skipping any metric
+// that rs.metricFilters excludes.
 func (rs *redisScraper) recordCommonMetrics(ts pcommon.Timestamp, inf info) {
 	recorders := rs.dataPointRecorders()
 	for infoKey, infoVal := range inf {
@@ -102,7 +173,10 @@ func (rs *redisScraper) recordCommonMetrics(ts pcommon.Timestamp, inf info) {
 			// Skip unregistered metric.
 			continue
 		}
-		switch recordDataPoint := recorder.(type) {
+		if !rs.metricFilters.allows(recorder.metric) {
+			continue
+		}
+		switch recordDataPoint := recorder.record.(type) {
 		case func(pcommon.Timestamp, int64):
 			val, err := strconv.ParseInt(infoVal, 10, 64)
 			if err != nil {
@@ -124,11 +198,11 @@ func (rs *redisScraper) recordCommonMetrics(ts pcommon.Timestamp, inf info) {
 // recordKeyspaceMetrics records metrics from 'keyspace' Redis info key-value pairs,
 // e.g. "db0: keys=1,expires=2,avg_ttl=3".
 func (rs *redisScraper) recordKeyspaceMetrics(ts pcommon.Timestamp, inf info) {
-	for db := 0; db < redisMaxDbs; db++ {
+	for _, db := range rs.keyspaceDatabases {
 		key := "db" + strconv.Itoa(db)
 		str, ok := inf[key]
 		if !ok {
-			break
+			continue
 		}
 		keyspace, parsingError := parseKeyspaceString(db, str)
 		if parsingError != nil {
@@ -136,9 +210,15 @@ func (rs *redisScraper) recordKeyspaceMetrics(ts pcommon.Timestamp, inf info) {
 				zap.String("val", str), zap.Error(parsingError))
 			continue
 		}
-		rs.mb.RecordRedisDbKeysDataPoint(ts, int64(keyspace.keys), keyspace.db)
-		rs.mb.RecordRedisDbExpiresDataPoint(ts, int64(keyspace.expires), keyspace.db)
-		rs.mb.RecordRedisDbAvgTTLDataPoint(ts, int64(keyspace.avgTTL), keyspace.db)
+		if rs.metricFilters.allows("redis.db.keys") {
+			rs.mb.RecordRedisDbKeysDataPoint(ts, int64(keyspace.keys), keyspace.db)
+		}
+		if rs.metricFilters.allows("redis.db.expires") {
+			rs.mb.RecordRedisDbExpiresDataPoint(ts, int64(keyspace.expires), keyspace.db)
+		}
+		if rs.metricFilters.allows("redis.db.avg_ttl") {
+			rs.mb.RecordRedisDbAvgTTLDataPoint(ts, int64(keyspace.avgTTL), keyspace.db)
+		}
 	}
 }
 
@@ -159,18 +239,61 @@ func (rs *redisScraper) recordLatencyStatsMetrics(ts pcommon.Timestamp, inf info
 		}
 		for percentile, latency := range latencystats.stats {
 			switch percentile {
-			case "50":
-				rs.mb.RecordRedisLatencystatP50DataPoint(ts, float64(latency), command)
-			case "90":
-				rs.mb.RecordRedisLatencystatP90DataPoint(ts, float64(latency), command)
-			case "99":
-				rs.mb.RecordRedisLatencystatP99DataPoint(ts, float64(latency), command)
-			case "99.9":
-				rs.mb.RecordRedisLatencystatP999DataPoint(ts, float64(latency), command)
-			case "100":
-				rs.mb.RecordRedisLatencystatP100DataPoint(ts, float64(latency), command)
+			case "p50":
+				if rs.metricFilters.allows("redis.latencystat.p50") {
+					rs.mb.RecordRedisLatencystatP50DataPoint(ts, float64(latency), command)
+				}
+			case "p90":
+				if rs.metricFilters.allows("redis.latencystat.p90") {
+					rs.mb.RecordRedisLatencystatP90DataPoint(ts, float64(latency), command)
+				}
+			case "p99":
+				if rs.metricFilters.allows("redis.latencystat.p99") {
+					rs.mb.RecordRedisLatencystatP99DataPoint(ts, float64(latency), command)
+				}
+			case "p99.9":
+				if rs.metricFilters.allows("redis.latencystat.p99.9") {
+					rs.mb.RecordRedisLatencystatP999DataPoint(ts, float64(latency), command)
+				}
+			case "p100":
+				if rs.metricFilters.allows("redis.latencystat.p100") {
+					rs.mb.RecordRedisLatencystatP100DataPoint(ts, float64(latency), command)
+				}
 			}
 		}
 
 	}
 }
+
+// recordCommandStatsMetrics records metrics from 'commandstats' Redis info key-value pairs,
+// e.g. "cmdstat_get:calls=2,usec=14,usec_per_call=7.00,rejected_calls=0,failed_calls=0".
+func (rs *redisScraper) recordCommandStatsMetrics(ts pcommon.Timestamp, inf info) {
+	keyPrefix := "cmdstat_"
+	for infoKey, infoVal := range inf {
+		if (!strings.HasPrefix(infoKey, keyPrefix)) || len(infoKey) <= len(keyPrefix) {
+			continue
+		}
+		command := infoKey[len(keyPrefix):]
+		stats, parsingError := parseCommandStatsString(command, infoVal)
+		if parsingError != nil {
+			rs.settings.Logger.Warn("failed to parse commandstats string", zap.String("command", command),
+				zap.String("commandstats", infoVal), zap.Error(parsingError))
+			continue
+		}
+		if rs.metricFilters.allows("redis.cmdstat.calls") {
+			rs.mb.RecordRedisCmdstatCallsDataPoint(ts, stats.calls, command)
+		}
+		if rs.metricFilters.allows("redis.cmdstat.usec") {
+			rs.mb.RecordRedisCmdstatUsecDataPoint(ts, stats.usec, command)
+		}
+		if rs.metricFilters.allows("redis.cmdstat.usec_per_call") {
+			rs.mb.RecordRedisCmdstatUsecPerCallDataPoint(ts, stats.usecPerCall, command)
+		}
+		if rs.metricFilters.allows("redis.cmdstat.rejected_calls") {
+			rs.mb.RecordRedisCmdstatRejectedCallsDataPoint(ts, stats.rejectedCalls, command)
+		}
+		if rs.metricFilters.allows("redis.cmdstat.failed_calls") {
+			rs.mb.RecordRedisCmdstatFailedCallsDataPoint(ts, stats.failedCalls, command)
+		}
+	}
+}
@@ -39,7 +39,8 @@ func TestRedisRunnable(t *testing.T) {
 	require.NoError(t, err)
 	// + 6 because there are two keyspace entries each of which has three metrics
 	// + 15 because there are five command latency entries each of which has three percentile stats
-	assert.Equal(t, len(rs.dataPointRecorders())+6+15, md.DataPointCount())
+	// + 5 because there is one commandstats entry with five stats
+	assert.Equal(t, len(rs.dataPointRecorders())+6+15+5, md.DataPointCount())
 	rm := md.ResourceMetrics().At(0)
 	ilm := rm.ScopeMetrics().At(0)
 	il := ilm.Scope()
@@ -0,0 +1,62 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver"
+
+import (
+	"context"
+	"fmt"
+)
+
+// redisSvc fetches and parses INFO output from every node of a configured Redis deployment.
+type redisSvc struct {
+	factory clientFactory
+}
+
+func newRedisSvc(factory clientFactory) *redisSvc {
+	return &redisSvc{factory: factory}
+}
+
+// nodeInfo pairs a node's descriptor with its parsed INFO output.
+type nodeInfo struct {
+	nodeDescriptor
+	info info
+}
+
+// info queries every node discovered by the configured clientFactory and returns its parsed
+// INFO output. For a standalone deployment this is always a single-element slice.
+func (r *redisSvc) info(ctx context.Context) ([]nodeInfo, error) {
+	clients, err := r.factory.nodeClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]nodeInfo, 0, len(clients))
+	for _, nc := range clients {
+		raw, err := nc.retrieveInfo(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("retrieving info from %s: %w", nc.address, err)
+		}
+		parsed, err := parseInfo(raw)
+		if err != nil {
+			return nil, fmt.Errorf("parsing info from %s: %w", nc.address, err)
+		}
+		results = append(results, nodeInfo{nodeDescriptor: nc.nodeDescriptor, info: parsed})
+	}
+	return results, nil
+}
+
+// Close releases the connections held by r's clientFactory.
+func (r *redisSvc) Close() error {
+	return r.factory.Close()
+}
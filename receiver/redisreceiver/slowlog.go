@@ -0,0 +1,159 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/redisreceiver"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+)
+
+// slowlogReceiver periodically fetches the slowlog from every node of a Redis deployment
+// and emits each entry as a log record.
+type slowlogReceiver struct {
+	cfg      *Config
+	factory  clientFactory
+	consumer consumer.Logs
+	settings component.ReceiverCreateSettings
+
+	// seenByNode holds, per node address, the slowlog ids observed on the previous scrape.
+	// It's used to avoid re-emitting the same entry on every scrape when ResetAfterRead is
+	// false, and is naturally bounded to MaxEntries since it's replaced wholesale each scrape.
+	mu         sync.Mutex
+	seenByNode map[string]map[int64]struct{}
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newLogsReceiver(cfg *Config, settings component.ReceiverCreateSettings, consumer consumer.Logs) (component.LogsReceiver, error) {
+	opts, err := cfg.redisOptions()
+	if err != nil {
+		return nil, err
+	}
+	factory, err := newClientFactory(cfg, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &slowlogReceiver{
+		cfg:        cfg,
+		factory:    factory,
+		consumer:   consumer,
+		settings:   settings,
+		seenByNode: map[string]map[int64]struct{}{},
+	}, nil
+}
+
+func (r *slowlogReceiver) Start(_ context.Context, _ component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.wg.Add(1)
+	go r.run(ctx)
+	return nil
+}
+
+func (r *slowlogReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+	return r.factory.Close()
+}
+
+func (r *slowlogReceiver) run(ctx context.Context) {
+	defer r.wg.Done()
+	ticker := time.NewTicker(r.cfg.CollectionInterval)
+	defer ticker.Stop()
+	for {
+		if err := r.scrape(ctx); err != nil {
+			r.settings.Logger.Warn("failed to collect redis slowlog", zap.Error(err))
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *slowlogReceiver) scrape(ctx context.Context) error {
+	clients, err := r.factory.nodeClients(ctx)
+	if err != nil {
+		return err
+	}
+
+	logs := plog.NewLogs()
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, nc := range clients {
+		entries, err := nc.slowLogGet(ctx, r.cfg.Slowlog.MaxEntries)
+		if err != nil {
+			return fmt.Errorf("fetching slowlog from %s: %w", nc.address, err)
+		}
+
+		previouslySeen := r.seenByNode[nc.address]
+		nowSeen := make(map[int64]struct{}, len(entries))
+
+		rl := logs.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("redis.node.address", nc.address)
+		sl := rl.ScopeLogs().AppendEmpty()
+		sl.Scope().SetName("otelcol/redisreceiver")
+
+		for _, entry := range entries {
+			nowSeen[entry.ID] = struct{}{}
+			if !r.cfg.Slowlog.ResetAfterRead {
+				if _, ok := previouslySeen[entry.ID]; ok {
+					continue
+				}
+			}
+			r.appendLogRecord(sl.LogRecords(), entry)
+		}
+		r.seenByNode[nc.address] = nowSeen
+
+		if r.cfg.Slowlog.ResetAfterRead {
+			if err := nc.slowLogReset(ctx); err != nil {
+				r.settings.Logger.Warn("failed to reset slowlog", zap.String("address", nc.address), zap.Error(err))
+			}
+		}
+	}
+
+	if logs.LogRecordCount() == 0 {
+		return nil
+	}
+	return r.consumer.ConsumeLogs(ctx, logs)
+}
+
+func (r *slowlogReceiver) appendLogRecord(records plog.LogRecordSlice, entry redis.SlowLog) {
+	command := strings.Join(entry.Args, " ")
+
+	lr := records.AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(entry.Time))
+	lr.Body().SetStringVal(command)
+	attrs := lr.Attributes()
+	attrs.PutInt("redis.slowlog.id", entry.ID)
+	attrs.PutInt("redis.slowlog.duration_us", entry.Duration.Microseconds())
+	attrs.PutStr("redis.slowlog.command", command)
+	attrs.PutStr("redis.slowlog.client_addr", entry.ClientAddr)
+	attrs.PutStr("redis.slowlog.client_name", entry.ClientName)
+}
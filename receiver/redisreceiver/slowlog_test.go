@@ -0,0 +1,113 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redisreceiver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.uber.org/zap"
+)
+
+type fakeSlowlogClient struct {
+	entries []redis.SlowLog
+	resets  int
+}
+
+func (c *fakeSlowlogClient) retrieveInfo(context.Context) (string, error) { return fakeInfo, nil }
+
+func (c *fakeSlowlogClient) slowLogGet(context.Context, int64) ([]redis.SlowLog, error) {
+	return c.entries, nil
+}
+
+func (c *fakeSlowlogClient) slowLogReset(context.Context) error {
+	c.resets++
+	return nil
+}
+
+type fakeSlowlogClientFactory struct {
+	client *fakeSlowlogClient
+}
+
+func (f *fakeSlowlogClientFactory) nodeClients(context.Context) ([]nodeClient, error) {
+	return []nodeClient{{
+		client:         f.client,
+		nodeDescriptor: nodeDescriptor{address: "localhost:6379", role: "master"},
+	}}, nil
+}
+
+func (f *fakeSlowlogClientFactory) Close() error {
+	return nil
+}
+
+func TestSlowlogReceiverDeduplicatesWhenResetDisabled(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	settings := componenttest.NewNopReceiverCreateSettings()
+	settings.Logger = logger
+
+	fakeClient := &fakeSlowlogClient{entries: []redis.SlowLog{
+		{ID: 1, Time: time.Unix(100, 0), Duration: 10 * time.Microsecond, Args: []string{"GET", "foo"}},
+		{ID: 2, Time: time.Unix(101, 0), Duration: 20 * time.Microsecond, Args: []string{"SET", "foo", "bar"}},
+	}}
+	sink := &consumertest.LogsSink{}
+	r := &slowlogReceiver{
+		cfg:        &Config{Slowlog: SlowlogSettings{MaxEntries: 128}},
+		factory:    &fakeSlowlogClientFactory{client: fakeClient},
+		consumer:   sink,
+		settings:   settings,
+		seenByNode: map[string]map[int64]struct{}{},
+	}
+
+	require.NoError(t, r.scrape(context.Background()))
+	assert.Equal(t, 2, sink.LogRecordCount())
+	assert.Equal(t, 0, fakeClient.resets)
+
+	// Same two entries again: both should be deduplicated away.
+	require.NoError(t, r.scrape(context.Background()))
+	assert.Equal(t, 2, sink.LogRecordCount())
+
+	// A new entry shows up alongside the old ones: only it should be emitted.
+	fakeClient.entries = append(fakeClient.entries, redis.SlowLog{
+		ID: 3, Time: time.Unix(102, 0), Duration: 5 * time.Microsecond, Args: []string{"PING"},
+	})
+	require.NoError(t, r.scrape(context.Background()))
+	assert.Equal(t, 3, sink.LogRecordCount())
+}
+
+func TestSlowlogReceiverResetsWhenConfigured(t *testing.T) {
+	settings := componenttest.NewNopReceiverCreateSettings()
+	fakeClient := &fakeSlowlogClient{entries: []redis.SlowLog{
+		{ID: 1, Time: time.Unix(100, 0), Args: []string{"GET", "foo"}},
+	}}
+	sink := &consumertest.LogsSink{}
+	r := &slowlogReceiver{
+		cfg:        &Config{Slowlog: SlowlogSettings{MaxEntries: 128, ResetAfterRead: true}},
+		factory:    &fakeSlowlogClientFactory{client: fakeClient},
+		consumer:   sink,
+		settings:   settings,
+		seenByNode: map[string]map[int64]struct{}{},
+	}
+
+	require.NoError(t, r.scrape(context.Background()))
+	require.NoError(t, r.scrape(context.Background()))
+	assert.Equal(t, 2, sink.LogRecordCount())
+	assert.Equal(t, 2, fakeClient.resets)
+}